@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxBulkRequestBytes caps the size of a POST /mac/bulk body so a caller
+// can't exhaust memory with an enormous MAC list.
+const maxBulkRequestBytes = 5 << 20 // 5 MiB
+
+// BulkMACResult is one entry of the POST /mac/bulk response array, and also
+// the response shape for the single-MAC GET /mac/{mac} endpoint.
+type BulkMACResult struct {
+	MAC                 string   `json:"mac"`
+	Normalized          string   `json:"normalized,omitempty"`
+	OUI                 string   `json:"oui,omitempty"`
+	VendorName          string   `json:"vendor_name,omitempty"`
+	VendorAlternateName string   `json:"vendor_alternate_name,omitempty"`
+	Registry            string   `json:"registry,omitempty"`
+	Found               bool     `json:"found"`
+	Flags               MACFlags `json:"flags"`
+	Note                string   `json:"note,omitempty"`
+}
+
+type bulkMACRequest struct {
+	MACs []string `json:"macs"`
+}
+
+type BulkHandler struct {
+}
+
+// Lookup resolves a batch of MAC addresses in one request, streaming each
+// result as soon as it's computed rather than buffering the whole response.
+func (b BulkHandler) Lookup(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxBulkRequestBytes)
+
+	macs, err := parseBulkMACs(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	flusher, _ := w.(http.Flusher)
+	enc := json.NewEncoder(w)
+
+	w.Write([]byte("["))
+	for i, mac := range macs {
+		if i > 0 {
+			w.Write([]byte(","))
+		}
+		enc.Encode(lookupMAC(mac))
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+	w.Write([]byte("]"))
+}
+
+// parseBulkMACs reads the MAC list from either a JSON body
+// ({"macs": [...]}) or a text/plain body with one MAC per line.
+func parseBulkMACs(r *http.Request) ([]string, error) {
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "text/plain") {
+		return parseBulkMACsPlainText(r.Body)
+	}
+	return parseBulkMACsJSON(r.Body)
+}
+
+func parseBulkMACsJSON(body io.Reader) ([]string, error) {
+	var req bulkMACRequest
+	if err := json.NewDecoder(body).Decode(&req); err != nil {
+		return nil, fmt.Errorf("invalid JSON body: %w", err)
+	}
+	return req.MACs, nil
+}
+
+func parseBulkMACsPlainText(body io.Reader) ([]string, error) {
+	var macs []string
+
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		macs = append(macs, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return macs, nil
+}
+
+// lookupMAC normalizes and resolves a single MAC, reporting Found=false
+// rather than erroring on bad input so one malformed entry doesn't fail a
+// whole bulk batch. It backs both GET /mac/{mac} and POST /mac/bulk.
+func lookupMAC(mac string) BulkMACResult {
+	result := BulkMACResult{MAC: mac}
+
+	normalized, err := normalizeMac(mac)
+	if err != nil {
+		return result
+	}
+	result.Normalized = normalized
+	result.Flags = ClassifyMAC(normalized)
+
+	if result.Flags.LocallyAdministered {
+		result.Note = "locally administered address; no IEEE OUI assignment applies"
+		return result
+	}
+
+	oui := GetOUIFromNormalizedMAC(normalized)
+	if oui == nil {
+		return result
+	}
+
+	result.OUI = oui.OUI
+	result.VendorName = oui.VendorName
+	result.VendorAlternateName = oui.VendorAlternateName
+	result.Registry = oui.Registry
+	result.Found = true
+	return result
+}
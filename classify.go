@@ -0,0 +1,73 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+)
+
+// MACFlags holds properties derivable from the MAC address bits themselves,
+// independent of any IEEE OUI assignment.
+type MACFlags struct {
+	LocallyAdministered bool   `json:"locally_administered"`
+	Multicast           bool   `json:"multicast"`
+	Broadcast           bool   `json:"broadcast"`
+	GroupAddress        string `json:"group_address,omitempty"`
+	VRID                *int   `json:"vrid,omitempty"`
+}
+
+// HasSignal reports whether any bit of the classification is non-default,
+// i.e. this MAC is interesting even without an IEEE OUI assignment (a
+// locally-administered address, a multicast or broadcast, or a well-known
+// group address).
+func (f MACFlags) HasSignal() bool {
+	return f.LocallyAdministered || f.Multicast || f.Broadcast || f.GroupAddress != ""
+}
+
+// Well-known group address prefixes/values, as 12 hex-char normalized MACs.
+const (
+	groupLLDP        = "0180C200000E"
+	groupSTP         = "0180C2000000"
+	groupIPv4MCast   = "01005E"
+	groupIPv6MCast   = "3333"
+	groupVRRPPrefix  = "00005E0001"
+	broadcastAddress = "FFFFFFFFFFFF"
+)
+
+// ClassifyMAC computes the locally-administered, multicast, broadcast and
+// well-known-group-address bits for an already-normalized (12 hex char) MAC.
+// It is pure and independent of any OUI data, so it's reusable and testable
+// on its own.
+func ClassifyMAC(normalized string) MACFlags {
+	var flags MACFlags
+	if len(normalized) != 12 {
+		return flags
+	}
+	mac := strings.ToUpper(normalized)
+
+	firstByte, err := strconv.ParseUint(mac[0:2], 16, 8)
+	if err == nil {
+		flags.Multicast = firstByte&0x01 != 0
+		flags.LocallyAdministered = firstByte&0x02 != 0
+	}
+
+	flags.Broadcast = mac == broadcastAddress
+
+	switch {
+	case mac == groupLLDP:
+		flags.GroupAddress = "lldp"
+	case mac == groupSTP:
+		flags.GroupAddress = "stp"
+	case strings.HasPrefix(mac, groupIPv4MCast):
+		flags.GroupAddress = "ipv4-multicast"
+	case strings.HasPrefix(mac, groupIPv6MCast):
+		flags.GroupAddress = "ipv6-multicast"
+	case strings.HasPrefix(mac, groupVRRPPrefix):
+		flags.GroupAddress = "vrrp"
+		if vrid, err := strconv.ParseUint(mac[10:12], 16, 8); err == nil {
+			v := int(vrid)
+			flags.VRID = &v
+		}
+	}
+
+	return flags
+}
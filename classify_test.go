@@ -0,0 +1,81 @@
+package main
+
+import "testing"
+
+func TestClassifyMAC(t *testing.T) {
+	cases := []struct {
+		name       string
+		normalized string
+		want       MACFlags
+	}{
+		{
+			name:       "globally administered unicast",
+			normalized: "00BBCCDDEEFF", // 0x00 = 0000 0000, bit1 clear
+			want:       MACFlags{LocallyAdministered: false, Multicast: false},
+		},
+		{
+			name:       "locally administered bit set",
+			normalized: "0ABBCCDDEEFF", // 0x0A = 0000 1010, bit1 set
+			want:       MACFlags{LocallyAdministered: true, Multicast: false},
+		},
+		{
+			name:       "multicast bit set",
+			normalized: "01BBCCDDEEFF", // 0x01 = 0000 0001, bit0 set
+			want:       MACFlags{LocallyAdministered: false, Multicast: true},
+		},
+		{
+			name:       "broadcast",
+			normalized: "FFFFFFFFFFFF",
+			want:       MACFlags{LocallyAdministered: true, Multicast: true, Broadcast: true},
+		},
+		{
+			name:       "ipv4 multicast group",
+			normalized: "01005E001122",
+			want:       MACFlags{Multicast: true, GroupAddress: "ipv4-multicast"},
+		},
+		{
+			name:       "ipv6 multicast group",
+			normalized: "333300000001", // 0x33 = 0011 0011, bit1 and bit0 both set
+			want:       MACFlags{LocallyAdministered: true, Multicast: true, GroupAddress: "ipv6-multicast"},
+		},
+		{
+			name:       "lldp",
+			normalized: "0180C200000E",
+			want:       MACFlags{LocallyAdministered: false, Multicast: true, GroupAddress: "lldp"},
+		},
+		{
+			name:       "stp",
+			normalized: "0180C2000000",
+			want:       MACFlags{Multicast: true, GroupAddress: "stp"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ClassifyMAC(tc.normalized)
+			if got.LocallyAdministered != tc.want.LocallyAdministered ||
+				got.Multicast != tc.want.Multicast ||
+				got.Broadcast != tc.want.Broadcast ||
+				got.GroupAddress != tc.want.GroupAddress {
+				t.Fatalf("ClassifyMAC(%q) = %+v, want %+v", tc.normalized, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestClassifyMACVRRP(t *testing.T) {
+	flags := ClassifyMAC("00005E000105")
+	if flags.GroupAddress != "vrrp" {
+		t.Fatalf("GroupAddress = %q, want vrrp", flags.GroupAddress)
+	}
+	if flags.VRID == nil || *flags.VRID != 5 {
+		t.Fatalf("VRID = %v, want 5", flags.VRID)
+	}
+}
+
+func TestClassifyMACInvalidLength(t *testing.T) {
+	got := ClassifyMAC("AABB")
+	if (got != MACFlags{}) {
+		t.Fatalf("ClassifyMAC(short input) = %+v, want zero value", got)
+	}
+}
@@ -3,107 +3,65 @@ package main
 import (
 	"encoding/json"
 	"errors"
+	"flag"
 	"log"
 	"net/http"
-	"os"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/cors"
 )
 
-var allOUIs []*OUIData
-
-type OUIData struct {
-	OUI                 string `json:"oui"`
-	VendorName          string `json:"vendor_name"`
-	VendorAlternateName string `json:"vendor_alternate_name"`
-}
-
-func NewOUI(oui string, vendorName string, vendorAlternateName string) (m OUIData, e error) {
-	_, err := strconv.ParseInt(oui, 16, 48)
-	if err != nil {
-		log.Fatal(err)
-		return m, errors.New("Unable to parse OUI")
-	}
-
-	m.OUI = oui
-	m.VendorName = strings.TrimSpace(vendorName)
-	m.VendorAlternateName = strings.TrimSpace(vendorAlternateName)
-	return m, nil
-}
-
-func ListOUIs() []*OUIData {
-	return allOUIs
-}
-
-func GetOUI(oui string) *OUIData {
-	for _, o := range allOUIs {
-		if o.OUI == strings.ToUpper(oui) {
-			return o
-		}
-	}
-	return nil
-}
+var (
+	macSeparatorRepl = strings.NewReplacer(":", "", "-", "", ".", "", " ", "")
+	hexOnlyExp       = regexp.MustCompile(`^[0-9a-fA-F]+$`)
+)
 
-func makeMACHashMap(fileName string) map[string]*OUIData {
-	log.Println("Attempting to load OUIs and build hash map")
+// normalizeMac strips the common MAC separators (colons, dashes, dots,
+// Cisco-style dotted-quad spacing) and returns a 12 hex-character uppercase
+// MAC. It also accepts 16-character EUI-64 addresses, collapsing the
+// FFFE/FFFF insertion back down to the original 48-bit MAC.
+func normalizeMac(mac string) (string, error) {
+	stripped := macSeparatorRepl.Replace(mac)
 
-	data, err := os.ReadFile(fileName)
-	if err != nil {
-		panic(err)
+	if !hexOnlyExp.MatchString(stripped) {
+		return "", errors.New("MAC address contains non-hex characters")
 	}
 
-	text := string(data)
-	// Compile regex expression to match interesting lines
-	ouiExp := regexp.MustCompile(`(?m)^([0-9a-fA-F]{2}(-[0-9a-fA-F]{2}){2})\s+\(hex\)\s+(?P<vendorName>.*)\n(?P<OUI>[0-9a-fA-F]{6})\s+\(base 16\)\s+(?P<vendorAlternateName>.*)$`)
-
-	matches := ouiExp.FindAllStringSubmatch(text, -1)
-	results := map[string]*OUIData{}
-
-	for _, match := range matches {
-		oui := match[ouiExp.SubexpIndex("OUI")]
-		vendor := match[ouiExp.SubexpIndex("vendorName")]
-		vendorOtherName := match[ouiExp.SubexpIndex("vendorAlternateName")]
-
-		ouiEntry, err := NewOUI(oui, vendor, vendorOtherName)
-		if err != nil {
-			continue
-		}
-		results[ouiEntry.OUI] = &ouiEntry
-		allOUIs = append(allOUIs, &ouiEntry) // Messy but might as well append instead of opening the file again in another function
+	switch len(stripped) {
+	case 12:
+		return strings.ToUpper(stripped), nil
+	case 16:
+		return collapseEUI64(strings.ToUpper(stripped))
+	default:
+		return "", errors.New("Incorrect format of MAC address")
 	}
-
-	log.Printf("Finished loading OUI hash map, %v OUIs loaded", len(allOUIs))
-	return results
 }
 
-func normalizeMac(mac string) (string, error) {
-	r := regexp.MustCompile("[^a-zA-Z0-9 ]+")
-	mac = r.ReplaceAllString(mac, "")
-
-	if len(mac) != 12 {
-		return "", errors.New("Incorrect format of MAC address")
+// collapseEUI64 reverses the EUI-64 expansion of a 48-bit MAC (the vendor
+// half, FFFE or FFFF, then the device half) back to the original 12 hex
+// characters.
+func collapseEUI64(eui string) (string, error) {
+	middle := eui[6:10]
+	if middle != "FFFE" && middle != "FFFF" {
+		return "", errors.New("16-character address is not a recognized EUI-64 encoding")
 	}
-
-	return mac, nil
+	return eui[:6] + eui[10:], nil
 }
 
-func GetVendorFromMAC(mac string) string {
-	// Normalize MAC and then check first 6 characters
-	macToFind, err := normalizeMac(mac)
-	if err != nil {
-		log.Printf("Unable to find MAC %v", mac)
-		return ""
-	}
-	macOUI := GetOUI(macToFind[:6])
-	if macOUI != nil {
-		return macOUI.VendorName
+// GetOUIFromNormalizedMAC resolves an already-normalized (12 hex char) MAC
+// against the current OUI snapshot, returning the deepest MA-L/MA-M/MA-S
+// assignment that matches.
+func GetOUIFromNormalizedMAC(macToFind string) *OUIData {
+	snap := currentSnapshot.Load()
+	if snap == nil {
+		return nil
 	}
-
-	return ""
+	return snap.trie.longestMatch(macToFind)
 }
 
 type MACHandler struct {
@@ -111,11 +69,18 @@ type MACHandler struct {
 
 func (m MACHandler) GetOUIFromMAC(w http.ResponseWriter, r *http.Request) {
 	macParam := chi.URLParam(r, "mac")
-	vendor  := GetVendorFromMAC(macParam)
-	if vendor == "" {
+
+	result := lookupMAC(macParam)
+	if result.Normalized == "" {
+		http.Error(w, "Unable to parse MAC address", http.StatusBadRequest)
+		return
+	}
+	if !result.Found && !result.Flags.HasSignal() {
 		http.Error(w, "Unable to find OUI Vendor for this MAC address", http.StatusNotFound)
+		return
 	}
-	err := json.NewEncoder(w).Encode(vendor)
+
+	err := json.NewEncoder(w).Encode(result)
 	if err != nil {
 		http.Error(w, "Internal Error", http.StatusInternalServerError)
 		return
@@ -135,9 +100,21 @@ func (m OUIHandler) ListOUIS(w http.ResponseWriter, r *http.Request) {
 
 func (m OUIHandler) GetOUI(w http.ResponseWriter, r *http.Request) {
 	ouiParam := chi.URLParam(r, "oui")
-	oui := GetOUI(ouiParam)
+
+	bits := 0
+	if bitsParam := r.URL.Query().Get("bits"); bitsParam != "" {
+		parsedBits, err := strconv.Atoi(bitsParam)
+		if err != nil {
+			http.Error(w, "Invalid bits query parameter", http.StatusBadRequest)
+			return
+		}
+		bits = parsedBits
+	}
+
+	oui := GetOUI(ouiParam, bits)
 	if oui == nil {
 		http.Error(w, "OUI not found", http.StatusNotFound)
+		return
 	}
 	err := json.NewEncoder(w).Encode(oui)
 	if err != nil {
@@ -157,21 +134,58 @@ func ouiRoutes() chi.Router {
 func macRoutes() chi.Router {
 	r := chi.NewRouter()
 	macHandler := MACHandler{}
+	bulkHandler := BulkHandler{}
 	r.Get("/{mac}", macHandler.GetOUIFromMAC)
+	r.Post("/bulk", bulkHandler.Lookup)
 	return r
 }
 
+var (
+	refreshInterval    = flag.Duration("refresh", 24*time.Hour, "interval between background IEEE OUI registry refreshes (0 disables)")
+	adminToken         = flag.String("admin-token", "", "bearer token required by POST /admin/refresh (empty disables the endpoint)")
+	corsAllowedOrigins = flag.String("cors-allowed-origins", "*", "comma-separated list of allowed CORS origins")
+	rateLimitRPS       = flag.Float64("rate-limit-rps", 10, "allowed requests per second per client IP")
+	rateLimitBurst     = flag.Int("rate-limit-burst", 20, "token bucket burst size per client IP")
+)
+
+func corsOptions() cors.Options {
+	return cors.Options{
+		AllowedOrigins: strings.Split(*corsAllowedOrigins, ","),
+		AllowedMethods: []string{"GET", "POST", "OPTIONS"},
+		AllowedHeaders: []string{"Accept", "Content-Type", "Authorization"},
+		MaxAge:         300,
+	}
+}
+
 func main() {
+	flag.Parse()
+
 	// Load MAC data
-	makeMACHashMap("oui.txt")
+	buildOUIIndex()
+
+	if *refreshInterval > 0 {
+		go startBackgroundRefresh(*refreshInterval)
+	}
+
+	limiter := newIPRateLimiter(*rateLimitRPS, *rateLimitBurst)
 
 	r := chi.NewRouter()
+	r.Use(middleware.RequestID)
+	r.Use(middleware.RealIP)
 	r.Use(middleware.Logger)
+	r.Use(middleware.Recoverer)
+	r.Use(middleware.Compress(5, "application/json"))
+	r.Use(middleware.Timeout(10 * time.Second))
+	r.Use(cors.Handler(corsOptions()))
+	r.Use(limiter.Middleware)
 	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte("app is ok!"))
 	})
+	r.Get("/healthz", HealthzHandler)
+	r.Post("/admin/refresh", AdminRefreshHandler)
 	r.Mount("/oui", ouiRoutes())
 	r.Mount("/mac", macRoutes())
+	r.Mount("/vendor", vendorRoutes())
 	log.Println("Starting web server...")
 	http.ListenAndServe(":3000", r)
 }
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// Registry identifies which IEEE assignment block an OUIData entry came from.
+const (
+	RegistryMAL = "MA-L" // 24-bit, oui.txt
+	RegistryMAM = "MA-M" // 28-bit, oui28.txt
+	RegistryMAS = "MA-S" // 36-bit, oui36.txt
+)
+
+// ouiSnapshot is an immutable view of all loaded OUI data: the flat list
+// (for listing/exact lookups) and the trie (for longest-prefix MAC lookups).
+// Refreshing the registry builds a new snapshot and swaps it in atomically
+// so readers never observe a partially-loaded state.
+type ouiSnapshot struct {
+	all  []*OUIData
+	trie *ouiTrie
+}
+
+var currentSnapshot atomic.Pointer[ouiSnapshot]
+
+type OUIData struct {
+	OUI                 string `json:"oui"`
+	VendorName          string `json:"vendor_name"`
+	VendorAlternateName string `json:"vendor_alternate_name"`
+	Registry            string `json:"registry"`
+}
+
+func NewOUI(oui string, vendorName string, vendorAlternateName string, registry string) (m OUIData, e error) {
+	_, err := strconv.ParseInt(oui, 16, 64)
+	if err != nil {
+		return m, fmt.Errorf("unable to parse OUI %q: %w", oui, err)
+	}
+
+	m.OUI = strings.ToUpper(oui)
+	m.VendorName = strings.TrimSpace(vendorName)
+	m.VendorAlternateName = strings.TrimSpace(vendorAlternateName)
+	m.Registry = registry
+	return m, nil
+}
+
+func ListOUIs() []*OUIData {
+	snap := currentSnapshot.Load()
+	if snap == nil {
+		return nil
+	}
+	return snap.all
+}
+
+// GetOUI returns the entry at the exact depth of oui (an exact-length
+// prefix, not a longest-prefix match). When bits is non-zero the result is
+// discarded unless it came from the matching registry (24/28/36), so a
+// caller passing e.g. ?bits=28 never gets back an MA-L entry.
+func GetOUI(oui string, bits int) *OUIData {
+	snap := currentSnapshot.Load()
+	if snap == nil {
+		return nil
+	}
+
+	entry := snap.trie.lookup(oui)
+	if entry == nil {
+		return nil
+	}
+	if bits != 0 && registryBits(entry.Registry) != bits {
+		return nil
+	}
+	return entry
+}
+
+func registryBits(registry string) int {
+	switch registry {
+	case RegistryMAL:
+		return 24
+	case RegistryMAM:
+		return 28
+	case RegistryMAS:
+		return 36
+	}
+	return 0
+}
+
+// ouiSource describes one of the IEEE registry files we ingest. hexDigits is
+// the length of the "(base 16)" field for that registry (6/7/9 hex chars for
+// 24/28/36-bit blocks respectively).
+type ouiSource struct {
+	fileName  string
+	registry  string
+	hexDigits int
+}
+
+var ouiSources = []ouiSource{
+	{"oui.txt", RegistryMAL, 6},
+	{"oui28.txt", RegistryMAM, 7},
+	{"oui36.txt", RegistryMAS, 9},
+}
+
+// ouiFileExp matches the IEEE registry line-pair format shared by oui.txt,
+// oui28.txt and oui36.txt: a "(hex)" line with dash-separated hex digits and
+// vendor name, followed by a "(base 16)" line with the packed hex prefix and
+// an alternate vendor name/address line.
+var ouiFileExp = regexp.MustCompile(`(?m)^(?:[0-9a-fA-F]-?)+\s+\(hex\)\s+(?P<vendorName>.*)\n(?P<OUI>[0-9a-fA-F]+)\s+\(base 16\)\s+(?P<vendorAlternateName>.*)$`)
+
+// parseOUIText parses the raw contents of an IEEE registry file/download and
+// returns its entries tagged with the given registry.
+func parseOUIText(data []byte, registry string, hexDigits int) []*OUIData {
+	matches := ouiFileExp.FindAllStringSubmatch(string(data), -1)
+	results := make([]*OUIData, 0, len(matches))
+
+	for _, match := range matches {
+		oui := match[ouiFileExp.SubexpIndex("OUI")]
+		if len(oui) != hexDigits {
+			continue
+		}
+		vendor := match[ouiFileExp.SubexpIndex("vendorName")]
+		vendorOtherName := match[ouiFileExp.SubexpIndex("vendorAlternateName")]
+
+		ouiEntry, err := NewOUI(oui, vendor, vendorOtherName, registry)
+		if err != nil {
+			continue
+		}
+		results = append(results, &ouiEntry)
+	}
+
+	return results
+}
+
+// loadOUIFile parses an IEEE registry file and returns its entries tagged
+// with the given registry. Missing files are skipped rather than fatal,
+// since not every deployment ships oui28.txt/oui36.txt.
+func loadOUIFile(src ouiSource) []*OUIData {
+	data, err := os.ReadFile(src.fileName)
+	if err != nil {
+		log.Printf("Skipping %v registry (%v): %v", src.registry, src.fileName, err)
+		return nil
+	}
+	return parseOUIText(data, src.registry, src.hexDigits)
+}
+
+// buildSnapshot assembles a snapshot (flat list + trie) from the given
+// per-registry entry lists, without touching currentSnapshot.
+func buildSnapshot(entryLists ...[]*OUIData) *ouiSnapshot {
+	snap := &ouiSnapshot{trie: newOUITrie()}
+	for _, entries := range entryLists {
+		for _, entry := range entries {
+			snap.all = append(snap.all, entry)
+			snap.trie.insert(entry.OUI, entry)
+		}
+	}
+	return snap
+}
+
+// buildOUIIndex loads every known registry file from disk, stores the
+// resulting snapshot in currentSnapshot and returns it.
+func buildOUIIndex() *ouiSnapshot {
+	log.Println("Attempting to load OUIs and build lookup trie")
+
+	entryLists := make([][]*OUIData, 0, len(ouiSources))
+	for _, src := range ouiSources {
+		entryLists = append(entryLists, loadOUIFile(src))
+	}
+
+	snap := buildSnapshot(entryLists...)
+	currentSnapshot.Store(snap)
+
+	log.Printf("Finished loading OUI trie, %v OUIs loaded", len(snap.all))
+	return snap
+}
+
+// ouiTrieNode is one hex nibble of depth in the trie. data is non-nil when a
+// registry assignment terminates at exactly this depth.
+type ouiTrieNode struct {
+	children [16]*ouiTrieNode
+	data     *OUIData
+}
+
+// ouiTrie resolves MAC prefixes to the deepest (most specific) OUIData
+// assignment, since MA-M/MA-S entries subdivide a 24-bit MA-L block.
+type ouiTrie struct {
+	root *ouiTrieNode
+}
+
+func newOUITrie() *ouiTrie {
+	return &ouiTrie{root: &ouiTrieNode{}}
+}
+
+// insert adds prefixHex (a string of hex nibbles, no separators) to the trie,
+// attaching data to the node at its final depth.
+func (t *ouiTrie) insert(prefixHex string, data *OUIData) {
+	node := t.root
+	for _, c := range strings.ToUpper(prefixHex) {
+		idx := hexNibbleIndex(c)
+		if idx < 0 {
+			return
+		}
+		if node.children[idx] == nil {
+			node.children[idx] = &ouiTrieNode{}
+		}
+		node = node.children[idx]
+	}
+	node.data = data
+}
+
+// lookup returns the entry at the exact depth of prefixHex, or nil if no
+// registry assignment terminates there.
+func (t *ouiTrie) lookup(prefixHex string) *OUIData {
+	node := t.root
+	for _, c := range strings.ToUpper(prefixHex) {
+		idx := hexNibbleIndex(c)
+		if idx < 0 || node.children[idx] == nil {
+			return nil
+		}
+		node = node.children[idx]
+	}
+	return node.data
+}
+
+// longestMatch walks macHex (up to 9 hex nibbles, i.e. 36 bits) one nibble at
+// a time and returns the deepest assignment found. This naturally prefers a
+// 28/36-bit MA-M/MA-S entry over the 24-bit MA-L block it was carved out of,
+// including the case where IEEE marks that 24-bit block "Private".
+func (t *ouiTrie) longestMatch(macHex string) *OUIData {
+	node := t.root
+	var best *OUIData
+
+	for i, c := range strings.ToUpper(macHex) {
+		if i >= 9 {
+			break
+		}
+		idx := hexNibbleIndex(c)
+		if idx < 0 || node.children[idx] == nil {
+			break
+		}
+		node = node.children[idx]
+		if node.data != nil {
+			best = node.data
+		}
+	}
+
+	return best
+}
+
+func hexNibbleIndex(c rune) int {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c - '0')
+	case c >= 'A' && c <= 'F':
+		return int(c-'A') + 10
+	case c >= 'a' && c <= 'f':
+		return int(c-'a') + 10
+	}
+	return -1
+}
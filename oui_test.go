@@ -0,0 +1,52 @@
+package main
+
+import "testing"
+
+func TestOUITrieLongestMatchPrefersDeeperRegistry(t *testing.T) {
+	mal := &OUIData{OUI: "70B3D5", VendorName: "Private", Registry: RegistryMAL}
+	mam := &OUIData{OUI: "70B3D50", VendorName: "Sub-Assignee A", Registry: RegistryMAM}
+	mas := &OUIData{OUI: "70B3D5000", VendorName: "Sub-Assignee B", Registry: RegistryMAS}
+
+	trie := newOUITrie()
+	trie.insert(mal.OUI, mal)
+	trie.insert(mam.OUI, mam)
+	trie.insert(mas.OUI, mas)
+
+	// A MAC under the MA-S block should resolve to the 36-bit entry even
+	// though the 24-bit MA-L block it's carved out of is marked "Private".
+	if got := trie.longestMatch("70B3D50001AA"); got != mas {
+		t.Fatalf("longestMatch MA-S range = %v, want %v", got, mas)
+	}
+
+	// A MAC under the MA-M block (but outside the MA-S sub-block) should
+	// resolve to the 28-bit entry.
+	if got := trie.longestMatch("70B3D50234AB"); got != mam {
+		t.Fatalf("longestMatch MA-M range = %v, want %v", got, mam)
+	}
+
+	// A MAC sharing only the 24-bit prefix falls back to the MA-L entry.
+	if got := trie.longestMatch("70B3D5FF0011"); got != mal {
+		t.Fatalf("longestMatch MA-L range = %v, want %v", got, mal)
+	}
+
+	if got := trie.longestMatch("AABBCC001122"); got != nil {
+		t.Fatalf("longestMatch unknown prefix = %v, want nil", got)
+	}
+}
+
+func TestOUITrieLookupExactDepth(t *testing.T) {
+	mal := &OUIData{OUI: "AABBCC", VendorName: "Exact Vendor", Registry: RegistryMAL}
+
+	trie := newOUITrie()
+	trie.insert(mal.OUI, mal)
+
+	if got := trie.lookup("AABBCC"); got != mal {
+		t.Fatalf("lookup exact depth = %v, want %v", got, mal)
+	}
+	if got := trie.lookup("AABBC"); got != nil {
+		t.Fatalf("lookup shallower depth = %v, want nil", got)
+	}
+	if got := trie.lookup("AABBCCDD"); got != nil {
+		t.Fatalf("lookup deeper depth = %v, want nil", got)
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// idleLimiterTTL is how long a client IP's bucket is kept after its last
+// request before the sweep reclaims it. The background refresh flows
+// through once-a-day HTTP calls, not user traffic, so this only needs to
+// outlive ordinary request bursts.
+const idleLimiterTTL = 10 * time.Minute
+
+// limiterEntry pairs a token bucket with the last time it was touched, so
+// the sweep loop can evict entries for clients that stopped sending traffic.
+type limiterEntry struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// ipRateLimiter hands out a token-bucket rate.Limiter per client IP, keyed
+// on the RealIP middleware's resolved address. Idle entries are swept
+// periodically so a stream of distinct client IPs can't grow the map
+// without bound.
+type ipRateLimiter struct {
+	mu       sync.Mutex
+	limiters map[string]*limiterEntry
+	rps      rate.Limit
+	burst    int
+}
+
+func newIPRateLimiter(rps float64, burst int) *ipRateLimiter {
+	l := &ipRateLimiter{
+		limiters: map[string]*limiterEntry{},
+		rps:      rate.Limit(rps),
+		burst:    burst,
+	}
+	go l.sweepLoop()
+	return l
+}
+
+func (l *ipRateLimiter) limiterFor(ip string) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry, ok := l.limiters[ip]
+	if !ok {
+		entry = &limiterEntry{limiter: rate.NewLimiter(l.rps, l.burst)}
+		l.limiters[ip] = entry
+	}
+	entry.lastSeen = time.Now()
+	return entry.limiter
+}
+
+// sweepLoop evicts limiter entries that have been idle longer than
+// idleLimiterTTL, bounding memory use under churn from many distinct
+// client IPs.
+func (l *ipRateLimiter) sweepLoop() {
+	ticker := time.NewTicker(idleLimiterTTL)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		cutoff := time.Now().Add(-idleLimiterTTL)
+
+		l.mu.Lock()
+		for ip, entry := range l.limiters {
+			if entry.lastSeen.Before(cutoff) {
+				delete(l.limiters, ip)
+			}
+		}
+		l.mu.Unlock()
+	}
+}
+
+// clientIP strips the ephemeral source port from r.RemoteAddr so repeat
+// connections from the same client share one bucket instead of each getting
+// a fresh one.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// Middleware rejects requests with 429 once a client IP exceeds its token
+// bucket. Must run after chi's RealIP middleware so r.RemoteAddr reflects
+// the real client rather than a proxy hop.
+func (l *ipRateLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !l.limiterFor(clientIP(r)).Allow() {
+			http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ouiRemoteSource mirrors ouiSource but for IEEE's HTTP-published registries,
+// used by the background refresher instead of the local startup files.
+type ouiRemoteSource struct {
+	url       string
+	registry  string
+	hexDigits int
+}
+
+var ouiRemoteSources = []ouiRemoteSource{
+	{"https://standards-oui.ieee.org/oui/oui.txt", RegistryMAL, 6},
+	{"https://standards-oui.ieee.org/oui28/mam.txt", RegistryMAM, 7},
+	{"https://standards-oui.ieee.org/oui36/oui36.txt", RegistryMAS, 9},
+}
+
+var refreshHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// These track the outcome of the most recent refresh attempt, read by
+// HealthzHandler and updated under refreshMu.
+var (
+	refreshMu          sync.RWMutex
+	lastRefreshTime    time.Time
+	lastRefreshEntries int
+	lastRefreshError   string
+)
+
+// downloadOUIText fetches one IEEE registry file into memory. Downloads are
+// bounded by refreshHTTPClient's timeout rather than streamed to disk, since
+// the registry files are a few MB at most.
+func downloadOUIText(url string) ([]byte, error) {
+	resp, err := refreshHTTPClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %v fetching %v", resp.Status, url)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// fetchRemoteSnapshot downloads every remote registry and parses them into a
+// fresh snapshot. It returns an error (leaving the caller's previous snapshot
+// untouched) if the download fails or the result would be empty.
+func fetchRemoteSnapshot() (*ouiSnapshot, error) {
+	entryLists := make([][]*OUIData, 0, len(ouiRemoteSources))
+
+	for _, src := range ouiRemoteSources {
+		data, err := downloadOUIText(src.url)
+		if err != nil {
+			return nil, fmt.Errorf("downloading %v registry: %w", src.registry, err)
+		}
+		entryLists = append(entryLists, parseOUIText(data, src.registry, src.hexDigits))
+	}
+
+	snap := buildSnapshot(entryLists...)
+	if len(snap.all) == 0 {
+		return nil, fmt.Errorf("refresh produced zero OUI entries, refusing to swap")
+	}
+	return snap, nil
+}
+
+// refreshOnce downloads and validates a new snapshot and, on success,
+// atomically swaps it in. On failure the previous snapshot keeps serving
+// and the error is recorded for /healthz.
+func refreshOnce() error {
+	snap, err := fetchRemoteSnapshot()
+
+	refreshMu.Lock()
+	defer refreshMu.Unlock()
+
+	if err != nil {
+		lastRefreshError = err.Error()
+		log.Printf("OUI registry refresh failed, keeping previous snapshot: %v", err)
+		return err
+	}
+
+	currentSnapshot.Store(snap)
+	lastRefreshTime = time.Now()
+	lastRefreshEntries = len(snap.all)
+	lastRefreshError = ""
+	log.Printf("OUI registry refreshed, %v entries loaded", lastRefreshEntries)
+	return nil
+}
+
+// startBackgroundRefresh re-downloads the IEEE OUI registries on interval
+// until the process exits.
+func startBackgroundRefresh(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		refreshOnce()
+	}
+}
+
+type healthzResponse struct {
+	Status           string `json:"status"`
+	EntryCount       int    `json:"entry_count"`
+	LastRefreshTime  string `json:"last_refresh_time,omitempty"`
+	LastRefreshError string `json:"last_refresh_error,omitempty"`
+}
+
+func HealthzHandler(w http.ResponseWriter, r *http.Request) {
+	refreshMu.RLock()
+	resp := healthzResponse{
+		Status:           "ok",
+		EntryCount:       len(ListOUIs()),
+		LastRefreshError: lastRefreshError,
+	}
+	if !lastRefreshTime.IsZero() {
+		resp.LastRefreshTime = lastRefreshTime.Format(time.RFC3339)
+	}
+	refreshMu.RUnlock()
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		http.Error(w, "Internal Error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// AdminRefreshHandler forces an immediate registry reload. It is guarded by
+// a bearer token configured via -admin-token; when that flag is empty the
+// endpoint is disabled entirely.
+func AdminRefreshHandler(w http.ResponseWriter, r *http.Request) {
+	if *adminToken == "" {
+		http.Error(w, "admin refresh endpoint is disabled", http.StatusForbidden)
+		return
+	}
+	got := []byte(r.Header.Get("Authorization"))
+	want := []byte("Bearer " + *adminToken)
+	if len(got) != len(want) || subtle.ConstantTimeCompare(got, want) != 1 {
+		http.Error(w, "invalid or missing admin token", http.StatusUnauthorized)
+		return
+	}
+
+	if err := refreshOnce(); err != nil {
+		http.Error(w, fmt.Sprintf("refresh failed: %v", err), http.StatusBadGateway)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// defaultFuzzyThreshold is the Levenshtein distance at or below which a
+// vendor name is considered a fuzzy match when the caller doesn't specify one.
+const defaultFuzzyThreshold = 2
+
+// searchVendorSubstring finds every entry whose VendorName or
+// VendorAlternateName contains query (case-insensitive), including matches
+// that span a word boundary (e.g. "cisco systems" against "Cisco Systems,
+// Inc").
+func (s *ouiSnapshot) searchVendorSubstring(query string) []*OUIData {
+	query = strings.ToLower(query)
+
+	var results []*OUIData
+	for _, entry := range s.all {
+		if strings.Contains(strings.ToLower(entry.VendorName), query) ||
+			strings.Contains(strings.ToLower(entry.VendorAlternateName), query) {
+			results = append(results, entry)
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool { return results[i].OUI < results[j].OUI })
+	return results
+}
+
+// vendorFuzzyMatch pairs an entry with its best-case distance against query.
+type vendorFuzzyMatch struct {
+	entry    *OUIData
+	distance int
+}
+
+// searchVendorFuzzy ranks every entry by Levenshtein distance from query
+// against both vendor name fields, keeping matches within threshold.
+func (s *ouiSnapshot) searchVendorFuzzy(query string, threshold int) []*OUIData {
+	query = strings.ToLower(query)
+
+	var matches []vendorFuzzyMatch
+	for _, entry := range s.all {
+		distance := levenshteinDistance(query, strings.ToLower(entry.VendorName))
+		if altDistance := levenshteinDistance(query, strings.ToLower(entry.VendorAlternateName)); altDistance < distance {
+			distance = altDistance
+		}
+		if distance <= threshold {
+			matches = append(matches, vendorFuzzyMatch{entry: entry, distance: distance})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+		return matches[i].entry.OUI < matches[j].entry.OUI
+	})
+
+	results := make([]*OUIData, len(matches))
+	for i, m := range matches {
+		results[i] = m.entry
+	}
+	return results
+}
+
+// levenshteinDistance returns the classic single-character edit distance
+// between a and b.
+func levenshteinDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(curr[j-1]+1, prev[j]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	m := a
+	if b < m {
+		m = b
+	}
+	if c < m {
+		m = c
+	}
+	return m
+}
+
+type VendorHandler struct {
+}
+
+// Search handles GET /vendor/{query}, returning every OUIData whose vendor
+// name fields contain query, or (with ?fuzzy=1) rank by edit distance.
+func (v VendorHandler) Search(w http.ResponseWriter, r *http.Request) {
+	query := chi.URLParam(r, "query")
+
+	snap := currentSnapshot.Load()
+	if snap == nil {
+		http.Error(w, "OUI data not loaded yet", http.StatusServiceUnavailable)
+		return
+	}
+
+	var results []*OUIData
+	if r.URL.Query().Get("fuzzy") == "1" {
+		threshold := defaultFuzzyThreshold
+		if t := r.URL.Query().Get("threshold"); t != "" {
+			parsed, err := strconv.Atoi(t)
+			if err != nil {
+				http.Error(w, "Invalid threshold query parameter", http.StatusBadRequest)
+				return
+			}
+			threshold = parsed
+		}
+		results = snap.searchVendorFuzzy(query, threshold)
+	} else {
+		results = snap.searchVendorSubstring(query)
+	}
+
+	if err := json.NewEncoder(w).Encode(results); err != nil {
+		http.Error(w, "Internal Error", http.StatusInternalServerError)
+		return
+	}
+}
+
+func vendorRoutes() chi.Router {
+	r := chi.NewRouter()
+	vendorHandler := VendorHandler{}
+	r.Get("/{query}", vendorHandler.Search)
+	return r
+}